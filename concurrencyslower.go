@@ -50,6 +50,8 @@ package concurrencyslower
 import (
 	"runtime"
 	"sync"
+	"sync/atomic"
+	"unsafe"
 )
 
 const (
@@ -74,8 +76,11 @@ Obviously, this loop will only occupy a single (logical) CPU core. The natural G
 // `ConcurrentSum` attempts to make use of all available cores.
 func ConcurrentSum() int {
 	// Get the number of available logical cores. Usually this is 2*c where c is the number of physical cores and 2 is the number of hyperthreads per core.
-	n := runtime.GOMAXPROCS(0)
+	return ConcurrentSumN(runtime.GOMAXPROCS(0))
+}
 
+// `ConcurrentSumN` is `ConcurrentSum` with the goroutine count passed in explicitly, so callers can sweep it across several values.
+func ConcurrentSumN(n int) int {
 	// We need to collect the results from the `n` goroutines somewhere. How about a gloaal slice with one element for every goroutine.
 	sums := make([]int, n)
 
@@ -207,38 +212,54 @@ So let's change our concurrent loop so that each goroutine stores its intermedia
 
 // `ChannelSum()` spawns `n` goroutines that store their intermediate sums locally, then pass the result back through a channel.
 func ChannelSum() int {
-	n := runtime.GOMAXPROCS(0)
+	return ChannelSumN(runtime.GOMAXPROCS(0))
+}
 
-	// A channel of ints will collect all intermediate sums.
-	res := make(chan int)
+// `ChannelSumN` is `ChannelSum` with the goroutine count passed in explicitly, so callers can sweep it across several values.
+func ChannelSumN(n int) int {
+	sum := ParallelReduce(limit, n, func(start, end int) int64 {
+		// This local variable replaces the global slice.
+		sum := int64(0)
+		// Calculate the intermediate sum.
+		for j := start; j < end; j += 1 {
+			sum += int64(j)
+		}
+		return sum
+	}, func(a, b int64) int64 {
+		return a + b
+	})
+
+	return int(sum)
+}
 
-	for i := 0; i < n; i++ {
-		// The goroutine now receives a second parameter, the result channel. The arrow pointing "into" the `chan` keyword turns this channel into a send-only channel inside this function.
-		go func(i int, r chan<- int) {
-			// This local variable replaces the global slice.
-			sum := 0
-			// As before, we divide the input into `n` chunks of equal size.
-			start := (limit / n) * i
-			end := start + (limit / n)
-			// Calculate the intermediate sum.
-			for j := start; j < end; j += 1 {
-				sum += j
+/*
+## A reusable `ParallelReduce`
+
+The pattern behind `ChannelSum` - split a range into chunks, let each goroutine reduce its chunk into a goroutine-local value, and combine the partial results through a channel - is generally useful, and it is worth pulling out into its own function so that callers cannot accidentally reintroduce the shared-slice trap.
+*/
+
+// `ParallelReduce` divides `[0,n)` into `chunks` contiguous ranges, runs `worker` over each range in its own goroutine, and folds the `chunks` partial results with `combine`. The partial results never touch a shared slice - each one lives on the stack of the goroutine that produced it until it is sent over the channel - so callers of this function get the cache-friendly behavior of `ChannelSum` for free.
+func ParallelReduce(n int, chunks int, worker func(start, end int) int64, combine func(int64, int64) int64) int64 {
+	// A buffered channel of size `chunks` collects every partial result without making any goroutine wait to send.
+	res := make(chan int64, chunks)
+
+	for i := 0; i < chunks; i++ {
+		go func(i int) {
+			start := (n / chunks) * i
+			end := start + (n / chunks)
+			// The last chunk also takes the remainder when n does not divide evenly by chunks.
+			if i == chunks-1 {
+				end = n
 			}
-			// Pass the final sum into the channel.
-			r <- sum
-			// Call the goroutine and pass the CPU index and the channel.
-		}(i, res)
+			res <- worker(start, end)
+		}(i)
 	}
 
-	sum := 0
-	// This loop reads `n` values from the channel. We know exactly how many elements we will receive through the channel, hence we need no
-	for i := 0; i < n; i++ {
-		// Read a value from the channel and add it to `sum`.
-		//
-		//  The channel blocks when there are no elements to read. This provides a "natural" synchronization mechanism. The loop must wait until there is an element to read, and does not finish before all `n` elements have been passed through the channel.
-		sum += <-res
+	result := int64(0)
+	for i := 0; i < chunks; i++ {
+		result = combine(result, <-res)
 	}
-	return sum
+	return result
 }
 
 /*
@@ -261,6 +282,164 @@ Spreading the intermediate sums across individual local variables, rather than h
 However, how can we be sure that the individual variables never share the same cacheline? Well, starting a new goroutine allocates between 2KB and 8KB of data on the stack, which is way more than the typical cacheline size of 64 bytes. And since the intermediate sum variable is not referenced from anywhere outside the goroutine that creates it, it does not escape to the heap (where it could end up near to one of the other intermediate sum variables). So we can be pretty sure that no two intermediate sum variables will end up in the same cacheline.
 
 
+## Padding our way out of the shared slice
+
+Ditching the slice altogether is one way to dodge the cacheline sync dance, but it is not the only way. If we pad every slice element so that it occupies a whole cacheline on its own, no two elements can ever end up sharing one, and the slice-based version becomes just as fast as the channel-based one.
+
+*/
+
+const cacheLineSize = 64
+
+// `paddedInt` wraps an int with enough trailing padding to fill a whole cacheline, so that two adjacent slice elements never share one.
+type paddedInt struct {
+	v int
+	_ [cacheLineSize - unsafe.Sizeof(int(0))]byte
+}
+
+// `PaddedConcurrentSum` is `ConcurrentSum` with the shared slice padded to one cacheline per element.
+func PaddedConcurrentSum() int {
+	return PaddedConcurrentSumN(runtime.GOMAXPROCS(0))
+}
+
+// `PaddedConcurrentSumN` is `PaddedConcurrentSum` with the goroutine count passed in explicitly, so callers can sweep it across several values.
+func PaddedConcurrentSumN(n int) int {
+	// One `paddedInt` per goroutine, each on its own cacheline.
+	slots := make([]paddedInt, n)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			start := (limit / n) * i
+			end := start + (limit / n)
+
+			for j := start; j < end; j += 1 {
+				slots[i].v += j
+			}
+
+			wg.Done()
+		}(i)
+	}
+
+	wg.Wait()
+
+	sum := 0
+	for _, s := range slots {
+		sum += s.v
+	}
+	return sum
+}
+
+/*
+After adding `BenchmarkPaddedConcurrentSum` to the test file, the benchmark now lines up all four variants: serial, naively shared slice, padded slice, and channel-based local sums. The padded slice should come in close to the channel version, confirming that the slowdown was caused purely by sharing a cacheline, not by the algorithm itself.
+
+
+## Two more ways to share a single counter
+
+The slice-based variants share a whole cacheline's worth of counters. But what if all goroutines add up into one single shared counter instead? Two idiomatic ways to do that are an atomic add and a mutex-protected increment. Both avoid false sharing - there is only one variable, after all - but they trade it for *true* sharing: every goroutine fights over the very same cacheline on every single update.
+*/
+
+// `AtomicSum` has all goroutines add into a single `int64` via `atomic.AddInt64`.
+func AtomicSum() int {
+	return AtomicSumN(runtime.GOMAXPROCS(0))
+}
+
+// `AtomicSumN` is `AtomicSum` with the goroutine count passed in explicitly, so callers can sweep it across several values.
+func AtomicSumN(n int) int {
+	var sum int64
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			start := (limit / n) * i
+			end := start + (limit / n)
+
+			for j := start; j < end; j += 1 {
+				atomic.AddInt64(&sum, int64(j))
+			}
+
+			wg.Done()
+		}(i)
+	}
+
+	wg.Wait()
+
+	return int(sum)
+}
+
+// `MutexSum` has all goroutines add into a single `int64`, guarded by a `sync.Mutex`.
+func MutexSum() int {
+	n := runtime.GOMAXPROCS(0)
+
+	var (
+		mu  sync.Mutex
+		sum int64
+	)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			start := (limit / n) * i
+			end := start + (limit / n)
+
+			for j := start; j < end; j += 1 {
+				mu.Lock()
+				sum += int64(j)
+				mu.Unlock()
+			}
+
+			wg.Done()
+		}(i)
+	}
+
+	wg.Wait()
+
+	return int(sum)
+}
+
+/*
+Running all the variants side by side at a few different `GOMAXPROCS` settings shows the four distinct flavors of cache trouble: the naive shared slice suffers from false sharing, the atomic and mutex counters suffer from true sharing (every update bounces the same cacheline between cores), and only the channel and padded variants scale cleanly.
+
+
+## A second cache effect: how you slice the work matters too
+
+So far every concurrent variant has split `[0,limit)` into contiguous blocks, one per goroutine - exactly what `ChannelSum` does. That is not the only way to divide the work, though. An equally common pattern has goroutine `g` handle every `n`-th index instead: `g`, `g+n`, `g+2n`, and so on. This "striped" partitioning still splits the work evenly, but it accesses memory very differently.
+
+*/
+
+// `StripedConcurrentSum` splits the range the same way `ChannelSum` does - into `n` goroutine-local sums, collected through a channel - but goroutine `g` visits indices `g, g+n, g+2n, ...` instead of a contiguous block.
+func StripedConcurrentSum() int {
+	n := runtime.GOMAXPROCS(0)
+
+	res := make(chan int64, n)
+
+	for g := 0; g < n; g++ {
+		go func(g int) {
+			sum := int64(0)
+			for j := g; j < limit; j += n {
+				sum += int64(j)
+			}
+			res <- sum
+		}(g)
+	}
+
+	sum := int64(0)
+	for i := 0; i < n; i++ {
+		sum += <-res
+	}
+	return int(sum)
+}
+
+/*
+Here, no two goroutines ever write to the same variable, so there is no cacheline sync dance to speak of. But when the per-iteration work touches other memory besides the loop index - a real-world slice or array, say - contiguous chunks let the CPU prefetch the next few elements it already expects to need, while a striped pattern jumps `n` elements on every step and defeats that prefetching. `BenchmarkContiguousSum` and `BenchmarkStripedSum` in the test file put `ChannelSum` and `StripedConcurrentSum` side by side so you can see the difference.
+
+
+## Quantifying the effect across core counts
+
+All the benchmarks so far report a single number per variant, at whatever `GOMAXPROCS` the machine running them happens to have. That hides how each variant *scales*: does doubling the goroutine count actually buy a proportional speedup, or does the cacheline traffic eat the gain? To see that, `ConcurrentSum`, `ChannelSum`, `PaddedConcurrentSum` and `AtomicSum` each grew an `*N` twin above that takes the goroutine count as a plain parameter instead of reading it off `runtime.GOMAXPROCS(0)`. `BenchmarkScaling` in the test file sweeps those twins over 1, 2, 4 and 8 goroutines and reports two derived metrics via `b.ReportMetric`: the speedup versus `SerialSum`, and the parallel efficiency (speedup divided by goroutine count). A reader can now see the efficiency curve collapse as goroutines increase for the false-sharing-prone variants, and stay close to 1.0 for `ChannelSum` and `PaddedConcurrentSum`.
+
 
 ## How to get and run the code
 
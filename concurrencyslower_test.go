@@ -1,6 +1,14 @@
 package concurrencyslower
 
-import "testing"
+import (
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// goroutineLevels are the GOMAXPROCS settings that the contention benchmarks sweep over.
+var goroutineLevels = []int{1, 2, 4, 8}
 
 func BenchmarkSerialSum(b *testing.B) {
 	for i := 0; i < b.N; i++ {
@@ -19,3 +27,119 @@ func BenchmarkChannelSum(b *testing.B) {
 		ChannelSum()
 	}
 }
+
+func BenchmarkPaddedConcurrentSum(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		PaddedConcurrentSum()
+	}
+}
+
+func BenchmarkAtomicSum(b *testing.B) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(0))
+	for _, n := range goroutineLevels {
+		b.Run(fmt.Sprintf("goroutines=%d", n), func(b *testing.B) {
+			runtime.GOMAXPROCS(n)
+			for i := 0; i < b.N; i++ {
+				AtomicSum()
+			}
+		})
+	}
+}
+
+func BenchmarkMutexSum(b *testing.B) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(0))
+	for _, n := range goroutineLevels {
+		b.Run(fmt.Sprintf("goroutines=%d", n), func(b *testing.B) {
+			runtime.GOMAXPROCS(n)
+			for i := 0; i < b.N; i++ {
+				MutexSum()
+			}
+		})
+	}
+}
+
+func BenchmarkContiguousSum(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		ChannelSum()
+	}
+}
+
+func BenchmarkStripedSum(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		StripedConcurrentSum()
+	}
+}
+
+// BenchmarkScaling sweeps each *N variant over goroutineLevels and reports, for every (variant, goroutine count) pair, its speedup over SerialSum and its parallel efficiency (speedup divided by goroutine count).
+func BenchmarkScaling(b *testing.B) {
+	scalingVariants := []struct {
+		name string
+		fn   func(n int) int
+	}{
+		{"ConcurrentSum", ConcurrentSumN},
+		{"ChannelSum", ChannelSumN},
+		{"PaddedConcurrentSum", PaddedConcurrentSumN},
+		{"AtomicSum", AtomicSumN},
+	}
+
+	// A single serial run gives us the baseline to compute speedup against.
+	serialStart := time.Now()
+	SerialSum()
+	serialNsPerOp := float64(time.Since(serialStart))
+
+	for _, v := range scalingVariants {
+		b.Run(v.name, func(b *testing.B) {
+			for _, n := range goroutineLevels {
+				b.Run(fmt.Sprintf("goroutines=%d", n), func(b *testing.B) {
+					for i := 0; i < b.N; i++ {
+						v.fn(n)
+					}
+					nsPerOp := float64(b.Elapsed()) / float64(b.N)
+					speedup := serialNsPerOp / nsPerOp
+					b.ReportMetric(speedup, "speedup")
+					b.ReportMetric(speedup/float64(n), "efficiency")
+				})
+			}
+		})
+	}
+}
+
+// TestParallelReduce checks that ParallelReduce produces the same result as a plain serial sum over [0,n), for several combinations of n and chunk counts - including ones where n does not divide evenly by chunks.
+func TestParallelReduce(t *testing.T) {
+	cases := []struct {
+		n, chunks int
+	}{
+		{0, 1},
+		{1, 1},
+		{10, 1},
+		{10, 3},
+		{100, 4},
+		{1000, 7},
+		{12345, 16},
+	}
+
+	sumWorker := func(start, end int) int64 {
+		sum := int64(0)
+		for j := start; j < end; j++ {
+			sum += int64(j)
+		}
+		return sum
+	}
+	add := func(a, b int64) int64 {
+		return a + b
+	}
+
+	for _, c := range cases {
+		got := ParallelReduce(c.n, c.chunks, sumWorker, add)
+
+		// ParallelReduce covers all of [0,n), even when n does not divide evenly by chunks, so it must match a serial loop over the whole range.
+		want := int64(0)
+		for j := 0; j < c.n; j++ {
+			want += int64(j)
+		}
+
+		if got != want {
+			t.Errorf("ParallelReduce(%d, %d, ...) = %d, want %d", c.n, c.chunks, got, want)
+		}
+	}
+}